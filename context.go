@@ -0,0 +1,45 @@
+/*
+ * Copyright 2018. bigpigeon. All rights reserved.
+ * Use of this source code is governed by a MIT style
+ * license that can be found in the LICENSE file.
+ */
+
+package toyorm
+
+import "context"
+
+// Tracer is an extension point for observability integrations (e.g.
+// otelsql-style OpenTelemetry spans) without toyorm depending on any
+// tracing package directly. HandlerInsertReturning and HandlerSyncTable call
+// StartSpan around the database calls they issue; the returned endFn is
+// deferred until that handler returns and receives its error.
+type Tracer interface {
+	StartSpan(ctx context.Context, op, table string) (context.Context, func(error))
+}
+
+// noopTracer is used when no Tracer is configured, so call sites never
+// need a nil check.
+type noopTracer struct{}
+
+func (noopTracer) StartSpan(ctx context.Context, op, table string) (context.Context, func(error)) {
+	return ctx, func(error) {}
+}
+
+// WithContext returns a copy of brick that threads ctx through every
+// handler in the chain it runs, and ultimately into the QueryContext/
+// ExecContext calls issued against the database. A ToyBrick created via
+// Toy.Model defaults to context.Background().
+func (t *ToyBrick) WithContext(ctx context.Context) *ToyBrick {
+	newBrick := t.copy()
+	newBrick.ctx = ctx
+	return newBrick
+}
+
+// Context returns the context currently attached to brick, defaulting to
+// context.Background() if WithContext was never called.
+func (t *ToyBrick) Context() context.Context {
+	if t.ctx == nil {
+		return context.Background()
+	}
+	return t.ctx
+}
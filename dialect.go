@@ -0,0 +1,26 @@
+/*
+ * Copyright 2018. bigpigeon. All rights reserved.
+ * Use of this source code is governed by a MIT style
+ * license that can be found in the LICENSE file.
+ */
+
+package toyorm
+
+// dialects holds every Dialect known to Open, keyed by the database/sql
+// driver name passed to it. Built-in dialects register themselves in init
+// below; third-party drivers (Oracle, TiDB, ...) call RegisterDialect from
+// their own package init to plug in without touching toyorm itself.
+var dialects = map[string]Dialect{}
+
+// RegisterDialect makes a Dialect available to Open under driverName. It is
+// typically called from an init function, mirroring how database/sql
+// drivers register themselves with sql.Register.
+func RegisterDialect(driverName string, d Dialect) {
+	dialects[driverName] = d
+}
+
+func init() {
+	RegisterDialect("mysql", MySqlDialect{})
+	RegisterDialect("sqlite3", Sqlite3Dialect{})
+	RegisterDialect("postgres", PostgresDialect{})
+}
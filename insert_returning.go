@@ -0,0 +1,46 @@
+/*
+ * Copyright 2018. bigpigeon. All rights reserved.
+ * Use of this source code is governed by a MIT style
+ * license that can be found in the LICENSE file.
+ */
+
+package toyorm
+
+// ReturningDialect is implemented by dialects (e.g. PostgresDialect) whose
+// driver does not support sql.Result.LastInsertId and instead capture a
+// generated primary key via "RETURNING" on the insert statement itself.
+type ReturningDialect interface {
+	Dialect
+	InsertAndReturnID(insert SqlExpr, model *Model) SqlExpr
+}
+
+// HandlerInsertReturning runs ahead of HandlerInsert in the "Insert"
+// chain. When brick's Dialect is a ReturningDialect and the model has an
+// auto-increment primary key, it builds each row's insert with
+// "RETURNING <primary key>", reads the generated id back with QueryRow,
+// and stops the chain — HandlerInsert's LastInsertId-based path never
+// runs, since lib/pq and pgx don't support it.
+func HandlerInsertReturning(brick *ToyBrick, r *Record) (next bool, err error) {
+	dialect, ok := brick.toy.Dialect.(ReturningDialect)
+	if !ok {
+		return true, nil
+	}
+	primary := brick.Model.GetOnePrimary()
+	if primary == nil || !primary.IsAutoIncrement() {
+		return true, nil
+	}
+
+	ctx, end := brick.toy.Tracer.StartSpan(brick.Context(), "Insert", brick.Model.Name)
+	defer func() { end(err) }()
+
+	for _, row := range r.Rows() {
+		expr := dialect.InsertAndReturnID(row.InsertExpr(), brick.Model)
+		var id int64
+		query, args := expr.Query(), expr.Args()
+		if err = brick.DB("Insert").QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+			return false, err
+		}
+		row.SetFieldValue(primary, id)
+	}
+	return false, nil
+}
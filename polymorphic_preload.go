@@ -0,0 +1,123 @@
+/*
+ * Copyright 2018. bigpigeon. All rights reserved.
+ * Use of this source code is governed by a MIT style
+ * license that can be found in the LICENSE file.
+ */
+
+package toyorm
+
+import "reflect"
+
+// PolymorphicBelongToPreload is the interface-typed counterpart to
+// BelongToPreload: ContainerField holds any one of several concrete
+// models, and TypeField is the sibling string column (e.g.
+// "CommentableType") that says which one. Unlike BelongToPreload, it has
+// no single SubModel — Registry maps each possible type discriminator to
+// its *Model.
+type PolymorphicBelongToPreload struct {
+	Model          *Model
+	ContainerField Field
+	TypeField      Field
+	RelationField  Field
+	Registry       map[string]*Model
+}
+
+// PolymorphicBelongToPreload builds the binding described above. field
+// must be an interface type (or *interface{}); typeField is the string
+// discriminator column read to decide, per row, which entry of registry
+// the row's relation id belongs to.
+func (t *Toy) PolymorphicBelongToPreload(model *Model, field Field, typeField Field, registry map[string]*Model) *PolymorphicBelongToPreload {
+	_type := LoopTypeIndirect(field.StructField().Type)
+	if _type.Kind() != reflect.Interface {
+		panic("toyorm: PolymorphicBelongToPreload container field must be an interface type")
+	}
+	relationField := model.GetFieldWithName(field.Name() + "ID")
+	if relationField == nil {
+		panic("toyorm: PolymorphicBelongToPreload cannot find relation field " + field.Name() + "ID on " + model.Name)
+	}
+	return &PolymorphicBelongToPreload{
+		Model:          model,
+		ContainerField: field,
+		TypeField:      typeField,
+		RelationField:  relationField,
+		Registry:       registry,
+	}
+}
+
+// PolymorphicBelongToPreload attaches a polymorphic BelongTo preload for
+// fieldName (the interface-typed container field) to a copy of brick, the
+// same attach-and-return-a-copy shape as BelongToPreload/OneToOnePreload/
+// etc. already use. typeFieldName is the sibling string discriminator
+// column (e.g. "CommentableType").
+func (t *ToyBrick) PolymorphicBelongToPreload(fieldName, typeFieldName string, registry map[string]*Model) *ToyBrick {
+	field := t.Model.GetFieldWithName(fieldName)
+	typeField := t.Model.GetFieldWithName(typeFieldName)
+	p := t.toy.PolymorphicBelongToPreload(t.Model, field, typeField, registry)
+
+	newBrick := t.copy()
+	newBrick.polymorphicPreload = append(append([]*PolymorphicBelongToPreload{}, t.polymorphicPreload...), p)
+	return newBrick
+}
+
+// polymorphicPreloads returns every PolymorphicBelongToPreload attached to
+// brick via PolymorphicBelongToPreload, for HandlerPolymorphicPreloadFind
+// to walk.
+func (t *ToyBrick) polymorphicPreloads() []*PolymorphicBelongToPreload {
+	return t.polymorphicPreload
+}
+
+// HandlerPolymorphicPreloadFind is the Find-chain participant for
+// PolymorphicBelongToPreload, mirroring HandlerPreloadFind: for every row
+// loaded so far it reads TypeField, groups the relation ids by the
+// concrete model that type names, issues one Find per concrete model
+// against Registry, and stitches each result back into ContainerField on
+// the owning row.
+func HandlerPolymorphicPreloadFind(brick *ToyBrick, r *Record) (next bool, err error) {
+	preloads := brick.polymorphicPreloads()
+	if len(preloads) == 0 {
+		return true, nil
+	}
+	for _, p := range preloads {
+		if err = p.find(brick, r); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (p *PolymorphicBelongToPreload) find(brick *ToyBrick, r *Record) error {
+	rowsByModel := map[*Model][]*Record{}
+	idsByModel := map[*Model][]interface{}{}
+
+	for _, row := range r.Rows() {
+		typeName, ok := row.FieldValue(p.TypeField).(string)
+		if !ok {
+			continue
+		}
+		subModel, ok := p.Registry[typeName]
+		if !ok {
+			continue
+		}
+		rowsByModel[subModel] = append(rowsByModel[subModel], row)
+		idsByModel[subModel] = append(idsByModel[subModel], row.FieldValue(p.RelationField))
+	}
+
+	for subModel, ids := range idsByModel {
+		related, err := NewToyBrick(brick.toy, subModel).
+			Where(ExprIn, subModel.GetOnePrimary().Name(), ids).
+			FindRecords()
+		if err != nil {
+			return err
+		}
+		byPrimary := map[interface{}]*Record{}
+		for _, rec := range related {
+			byPrimary[rec.FieldValue(subModel.GetOnePrimary())] = rec
+		}
+		for _, row := range rowsByModel[subModel] {
+			if target, ok := byPrimary[row.FieldValue(p.RelationField)]; ok {
+				row.SetFieldValue(p.ContainerField, target.Source())
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright 2018. bigpigeon. All rights reserved.
+ * Use of this source code is governed by a MIT style
+ * license that can be found in the LICENSE file.
+ */
+
+package toyorm
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// PostgresDialect is the Dialect implementation for the "postgres" driver.
+// It differs from MySqlDialect mainly in placeholder style ($1, $2, ...),
+// identifier quoting ("..." instead of `...`), autoincrement columns
+// (SERIAL/BIGSERIAL) and primary key retrieval, which relies on
+// "RETURNING" instead of LastInsertId.
+type PostgresDialect struct {
+}
+
+func (PostgresDialect) Quote(name string) string {
+	return `"` + name + `"`
+}
+
+// Placeholder returns the positional placeholder for the i-th (0-based)
+// bound argument of a statement, e.g. Placeholder(0) == "$1".
+func (PostgresDialect) Placeholder(i int) string {
+	return fmt.Sprintf("$%d", i+1)
+}
+
+// HasTable reports whether the table exists via information_schema, since
+// postgres has no "SHOW TABLES" equivalent.
+func (PostgresDialect) HasTable(model *Model) SqlExpr {
+	return DefaultExprBuild(
+		"SELECT count(*) FROM information_schema.tables WHERE table_name = $1",
+		model.Name,
+	)
+}
+
+func (PostgresDialect) DropTable(model *Model) SqlExpr {
+	return DefaultExprBuild(fmt.Sprintf(`DROP TABLE "%s"`, model.Name))
+}
+
+// CreateTable renders a CREATE TABLE statement, substituting SERIAL or
+// BIGSERIAL for a primary key field flagged as auto-increment instead of emitting an
+// explicit type plus a driver-side AUTO_INCREMENT clause.
+func (d PostgresDialect) CreateTable(model *Model, setParams []string) []SqlExpr {
+	var columns []string
+	for _, field := range model.GetSqlFields() {
+		columns = append(columns, d.columnDefinition(field))
+	}
+	columns = append(columns, setParams...)
+	query := fmt.Sprintf(`CREATE TABLE "%s" (%s)`, model.Name, strings.Join(columns, ", "))
+	return []SqlExpr{DefaultExprBuild(query)}
+}
+
+func (d PostgresDialect) columnDefinition(field Field) string {
+	sqlType := d.DataTypeOf(field)
+	if field.IsPrimary() && field.IsAutoIncrement() {
+		switch sqlType {
+		case "bigint":
+			sqlType = "BIGSERIAL"
+		default:
+			sqlType = "SERIAL"
+		}
+	}
+	def := fmt.Sprintf(`"%s" %s`, field.Name(), sqlType)
+	if field.IsPrimary() {
+		def += " PRIMARY KEY"
+	}
+	return def
+}
+
+// DataTypeOf maps a Go field's reflect.Kind to the postgres column type used
+// for CREATE TABLE / Sync.
+func (PostgresDialect) DataTypeOf(field Field) string {
+	switch field.StructField().Type.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return "integer"
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return "bigint"
+	case reflect.Float32:
+		return "real"
+	case reflect.Float64:
+		return "double precision"
+	case reflect.String:
+		return "text"
+	case reflect.Slice:
+		if field.StructField().Type.Elem().Kind() == reflect.Uint8 {
+			return "bytea"
+		}
+	}
+	switch field.StructField().Type.String() {
+	case "time.Time":
+		return "timestamptz"
+	}
+	return "text"
+}
+
+// InspectTable reads name's live column set from information_schema, the
+// same source HasTable already queries, so PostgresDialect satisfies
+// SyncDialect and Toy.Sync/Toy.Migrate have at least one real dialect to
+// run against.
+func (PostgresDialect) InspectTable(ctx context.Context, db *sql.DB, name string) (*TableInfo, error) {
+	rows, err := db.QueryContext(ctx,
+		`SELECT column_name, data_type FROM information_schema.columns WHERE table_name = $1`,
+		name,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	info := &TableInfo{Name: name, Columns: map[string]string{}, Indexes: map[string]bool{}}
+	for rows.Next() {
+		var column, dataType string
+		if err := rows.Scan(&column, &dataType); err != nil {
+			return nil, err
+		}
+		info.Columns[column] = dataType
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(info.Columns) == 0 {
+		return nil, nil
+	}
+	return info, nil
+}
+
+// SyncTableExprs adds any column declared on model but missing from
+// current as a nullable ALTER TABLE ADD COLUMN; it never drops or narrows
+// an existing column, matching the "additive only" contract SyncDialect
+// documents. It emits no index statements and does not widen the type of
+// a column that already exists.
+func (d PostgresDialect) SyncTableExprs(model *Model, current *TableInfo) []SqlExpr {
+	var exprs []SqlExpr
+	for _, field := range model.GetSqlFields() {
+		if _, ok := current.Columns[field.Name()]; ok {
+			continue
+		}
+		query := fmt.Sprintf(`ALTER TABLE "%s" ADD COLUMN "%s" %s`, model.Name, field.Name(), d.DataTypeOf(field))
+		exprs = append(exprs, DefaultExprBuild(query))
+	}
+	return exprs
+}
+
+// InsertAndReturnID appends "RETURNING <primary key>" to an insert so the
+// generated key can be scanned out of the row instead of sql.Result's
+// LastInsertId, which postgres' driver does not support.
+func (PostgresDialect) InsertAndReturnID(insert SqlExpr, model *Model) SqlExpr {
+	primary := model.GetOnePrimary()
+	if primary == nil || !primary.IsAutoIncrement() {
+		return insert
+	}
+	return DefaultExprBuild(fmt.Sprintf(`%s RETURNING "%s"`, insert.Query(), primary.Name()), insert.Args()...)
+}
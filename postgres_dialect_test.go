@@ -0,0 +1,24 @@
+/*
+ * Copyright 2018. bigpigeon. All rights reserved.
+ * Use of this source code is governed by a MIT style
+ * license that can be found in the LICENSE file.
+ */
+
+package toyorm
+
+import "testing"
+
+func TestPostgresDialectQuote(t *testing.T) {
+	if got := (PostgresDialect{}).Quote("user"); got != `"user"` {
+		t.Errorf(`Quote("user") = %s, want "user"`, got)
+	}
+}
+
+func TestPostgresDialectPlaceholder(t *testing.T) {
+	cases := map[int]string{0: "$1", 1: "$2", 9: "$10"}
+	for i, want := range cases {
+		if got := (PostgresDialect{}).Placeholder(i); got != want {
+			t.Errorf("Placeholder(%d) = %s, want %s", i, got, want)
+		}
+	}
+}
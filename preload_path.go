@@ -0,0 +1,156 @@
+/*
+ * Copyright 2018. bigpigeon. All rights reserved.
+ * Use of this source code is governed by a MIT style
+ * license that can be found in the LICENSE file.
+ */
+
+package toyorm
+
+import "strings"
+
+// Associations is a sentinel passed to PreloadPath in place of a relation
+// name to mean "every relation declared on the current model", the same
+// role clause.Associations plays in gorm.
+const Associations = "Associations"
+
+// PreloadPath preloads a dotted relation path such as "User.Address" in a
+// single call, resolving each segment through the existing
+// BelongToPreload/OneToOnePreload/OneToManyPreload/ManyToManyPreload
+// caches on Toy. Associations may appear at any position to expand to
+// every relation discovered on that position's model.
+//
+// args is forwarded as a raw condition (e.g. "city = ?", "NYC") to the
+// deepest path segment's ToyBrick via Where, so
+// PreloadPath("Address", "city = ?", "NYC") only preloads addresses
+// matching that condition.
+func (t *ToyBrick) PreloadPath(path string, args ...interface{}) *ToyBrick {
+	tree := parsePreloadPath(map[string]interface{}{path: args})
+	return t.expandPreloadTree(tree, map[preloadVisit]bool{})
+}
+
+// preloadVisit tracks a (model, field) pair already expanded while walking
+// Associations, so preloading a self-referential model (e.g. a Category
+// with a Parent *Category) terminates instead of recursing forever.
+type preloadVisit struct {
+	model *Model
+	field string
+}
+
+// preloadNode is one path segment's expansion state: tail holds the
+// deeper dotted paths still to resolve (keyed and valued exactly like the
+// map parsePreloadPath itself accepts, so it can be fed straight back
+// in), and args holds the condition args when this segment is itself the
+// leaf of some path.
+type preloadNode struct {
+	tail map[string]interface{}
+	args []interface{}
+}
+
+// parsePreloadPath turns {"User.Address": args} into
+// {"User": {tail: {"Address": args}}}, and {"Address": args} (no dot)
+// into {"Address": {args: args}} — splitting the outermost relation name
+// off the remaining dotted tail, or attaching args directly when a path
+// has no further segments.
+func parsePreloadPath(paths map[string]interface{}) map[string]*preloadNode {
+	tree := map[string]*preloadNode{}
+	for path, args := range paths {
+		head, tail := path, ""
+		if i := strings.IndexByte(path, '.'); i >= 0 {
+			head, tail = path[:i], path[i+1:]
+		}
+		node := tree[head]
+		if node == nil {
+			node = &preloadNode{tail: map[string]interface{}{}}
+			tree[head] = node
+		}
+		if tail == "" {
+			if argSlice, ok := args.([]interface{}); ok {
+				node.args = argSlice
+			}
+			continue
+		}
+		node.tail[tail] = args
+	}
+	return tree
+}
+
+func (t *ToyBrick) expandPreloadTree(tree map[string]*preloadNode, visited map[preloadVisit]bool) *ToyBrick {
+	brick := t
+	for head, node := range tree {
+		if head == Associations {
+			brick = brick.expandAssociations(node, visited)
+			continue
+		}
+		brick = brick.preloadOne(head, node, visited)
+	}
+	return brick
+}
+
+// expandAssociations resolves Associations by trying every relation-shaped
+// field on the current model against each *Preload cache in turn, the same
+// probing order Toy already uses when a caller preloads a single field.
+func (t *ToyBrick) expandAssociations(node *preloadNode, visited map[preloadVisit]bool) *ToyBrick {
+	brick := t
+	for _, field := range t.Model.GetFields() {
+		brick = brick.preloadOne(field.Name(), node, visited)
+	}
+	return brick
+}
+
+// preloadOne resolves a single relation-name segment against every
+// preload cache on Toy, recurses into the sub-model's own ToyBrick with
+// the remaining dotted tail, applies node.args as a Where condition when
+// this segment is a leaf, and attaches the result to a copy of t.
+func (t *ToyBrick) preloadOne(name string, node *preloadNode, visited map[preloadVisit]bool) *ToyBrick {
+	field := t.Model.GetFieldWithName(name)
+	if field == nil {
+		return t
+	}
+	visit := preloadVisit{t.Model, name}
+	if visited[visit] {
+		return t
+	}
+	visited[visit] = true
+
+	var subModel *Model
+	switch {
+	case t.toy.BelongToPreload(t.Model, field) != nil:
+		subModel = t.toy.BelongToPreload(t.Model, field).SubModel
+	case t.toy.OneToOnePreload(t.Model, field) != nil:
+		subModel = t.toy.OneToOnePreload(t.Model, field).SubModel
+	case t.toy.OneToManyPreload(t.Model, field) != nil:
+		subModel = t.toy.OneToManyPreload(t.Model, field).SubModel
+	case t.toy.ManyToManyPreload(t.Model, field, true) != nil:
+		subModel = t.toy.ManyToManyPreload(t.Model, field, true).SubModel
+	default:
+		return t
+	}
+
+	subToyBrick := NewToyBrick(t.toy, subModel)
+	if len(node.tail) > 0 {
+		// Capture the expanded sub-brick — it carries any deeper dotted
+		// segments (e.g. the ".Address" of "User.Address") — instead of
+		// discarding it and attaching an unexpanded one.
+		subToyBrick = subToyBrick.expandPreloadTree(parsePreloadPath(node.tail), visited)
+	}
+	if len(node.args) > 0 {
+		if condition, ok := node.args[0].(string); ok {
+			subToyBrick = subToyBrick.Where(condition, node.args[1:]...)
+		}
+	}
+	return t.attachPreload(field, subToyBrick)
+}
+
+// attachPreload stores subToyBrick as the preload target for field on a
+// copy of t, the same copy-on-write shape WithContext/UsePrimary use
+// elsewhere in this package.
+func (t *ToyBrick) attachPreload(field Field, subToyBrick *ToyBrick) *ToyBrick {
+	newBrick := t.copy()
+	m := make(map[string]*ToyBrick, len(newBrick.preloadBrick)+1)
+	for k, v := range newBrick.preloadBrick {
+		m[k] = v
+	}
+	m[field.Name()] = subToyBrick
+	newBrick.preloadBrick = m
+	return newBrick
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright 2018. bigpigeon. All rights reserved.
+ * Use of this source code is governed by a MIT style
+ * license that can be found in the LICENSE file.
+ */
+
+package toyorm
+
+import "testing"
+
+func TestParsePreloadPathSplitsHeadFromTail(t *testing.T) {
+	tree := parsePreloadPath(map[string]interface{}{"User.Address.City": []interface{}{1}})
+	userNode, ok := tree["User"]
+	if !ok {
+		t.Fatalf("expected a \"User\" head, got %v", tree)
+	}
+	if _, ok := userNode.tail["Address.City"]; !ok {
+		t.Fatalf("expected the remaining dotted tail \"Address.City\", got %v", userNode.tail)
+	}
+	if len(userNode.args) != 0 {
+		t.Fatalf("a non-leaf segment should carry no args, got %v", userNode.args)
+	}
+}
+
+func TestParsePreloadPathNoDot(t *testing.T) {
+	tree := parsePreloadPath(map[string]interface{}{"User": nil})
+	node, ok := tree["User"]
+	if !ok || len(node.tail) != 0 {
+		t.Fatalf("a path with no dot should have an empty tail, got %v", tree)
+	}
+}
+
+func TestParsePreloadPathLeafArgs(t *testing.T) {
+	tree := parsePreloadPath(map[string]interface{}{"Address": []interface{}{"city = ?", "NYC"}})
+	node, ok := tree["Address"]
+	if !ok {
+		t.Fatalf("expected an \"Address\" head, got %v", tree)
+	}
+	if len(node.tail) != 0 {
+		t.Fatalf("a leaf segment should have an empty tail, got %v", node.tail)
+	}
+	if len(node.args) != 2 || node.args[0] != "city = ?" || node.args[1] != "NYC" {
+		t.Fatalf("leaf args should be forwarded unchanged, got %v", node.args)
+	}
+}
+
+func TestParsePreloadPathDottedLeafArgs(t *testing.T) {
+	// "User.Address" with args: args belong to the deepest segment
+	// ("Address"), not to "User".
+	tree := parsePreloadPath(map[string]interface{}{"User.Address": []interface{}{"city = ?", "NYC"}})
+	userNode := tree["User"]
+	if len(userNode.args) != 0 {
+		t.Fatalf("args must attach to the leaf segment, not the head, got %v", userNode.args)
+	}
+	addressTree := parsePreloadPath(userNode.tail)
+	addressNode, ok := addressTree["Address"]
+	if !ok {
+		t.Fatalf("expected an \"Address\" head once the tail is re-parsed, got %v", addressTree)
+	}
+	if len(addressNode.args) != 2 || addressNode.args[0] != "city = ?" {
+		t.Fatalf("leaf args should survive the recursive re-parse, got %v", addressNode.args)
+	}
+}
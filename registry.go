@@ -0,0 +1,164 @@
+/*
+ * Copyright 2018. bigpigeon. All rights reserved.
+ * Use of this source code is governed by a MIT style
+ * license that can be found in the LICENSE file.
+ */
+
+package toyorm
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// NameMapper turns a Go identifier (struct or field name) into the name
+// used on the database side. It is applied once, at registration time, to
+// both table and column names.
+type NameMapper interface {
+	Map(name string) string
+}
+
+// SnakeCaseMapper is the default NameMapper, turning "UserAddress" into
+// "user_address" and, unlike a naive per-rune lowercasing, keeping a run
+// of capitals together as one word — "UserID" becomes "user_id", not
+// "user_i_d" — so it matches GetBelongsIDFieldName's own "<Field>ID"
+// foreign-key convention.
+type SnakeCaseMapper struct{}
+
+func (SnakeCaseMapper) Map(name string) string {
+	return toSnakeCase(name)
+}
+
+// toSnakeCase only breaks a word in front of an uppercase letter that
+// either follows a lowercase letter ("dI" -> "d_i") or starts a new word
+// inside a run of capitals ("IDCard" -> "id_card", the "C" before a
+// lowercase "ard"). A run of capitals with no following lowercase letter
+// ("UserID" at the end of the string) is kept as a single word.
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && !unicode.IsUpper(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if i > 0 && (prevLower || nextLower) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// GonicMapper is a NameMapper for projects that want to keep a curated
+// set of initialisms (ID, API, URL, ...) intact even in positions
+// toSnakeCase's general heuristic might still split, the same role
+// golang/lint's commonInitialisms list plays for identifier casing. A nil
+// Initialisms falls back to a small built-in set.
+type GonicMapper struct {
+	Initialisms map[string]bool
+}
+
+var defaultInitialisms = map[string]bool{
+	"ID": true, "API": true, "URL": true, "URI": true, "HTTP": true,
+	"HTML": true, "JSON": true, "XML": true, "SQL": true, "UUID": true,
+}
+
+func (m GonicMapper) Map(name string) string {
+	initialisms := m.Initialisms
+	if initialisms == nil {
+		initialisms = defaultInitialisms
+	}
+	words := make([]string, 0, len(initialisms))
+	for word := range initialisms {
+		words = append(words, word)
+	}
+	// longest first, so "UUID" is replaced before "ID" can split it
+	sort.Slice(words, func(i, j int) bool { return len(words[i]) > len(words[j]) })
+	for _, word := range words {
+		name = strings.ReplaceAll(name, word, strings.ToUpper(word[:1])+strings.ToLower(word[1:]))
+	}
+	return toSnakeCase(name)
+}
+
+// SameNameMapper leaves names untouched.
+type SameNameMapper struct{}
+
+func (SameNameMapper) Map(name string) string { return name }
+
+// RegisterModel eagerly builds and caches the *Model for each v, so any
+// relation field that cannot be resolved panics at boot instead of at the
+// first query that needs it.
+func (t *Toy) RegisterModel(v ...interface{}) {
+	t.RegisterModelWithPrefix("", v...)
+}
+
+// RegisterModelWithPrefix is RegisterModel with every registered model's
+// table name prefixed, e.g. RegisterModelWithPrefix("t_", User{}) produces
+// table "t_user".
+func (t *Toy) RegisterModelWithPrefix(prefix string, v ...interface{}) {
+	t.registerModel(prefix, "", v...)
+}
+
+// RegisterModelWithSuffix is RegisterModel with every registered model's
+// table name suffixed.
+func (t *Toy) RegisterModelWithSuffix(suffix string, v ...interface{}) {
+	t.registerModel("", suffix, v...)
+}
+
+func (t *Toy) registerModel(prefix, suffix string, v ...interface{}) {
+	if t.registeredTypes == nil {
+		t.registeredTypes = map[reflect.Type]bool{}
+	}
+	for _, value := range v {
+		vType := LoopTypeIndirect(reflect.ValueOf(value).Type())
+		if t.registeredTypes[vType] {
+			// already registered (e.g. from two init paths); re-applying
+			// the prefix/suffix to the already-mapped name would compound
+			// it, so treat re-registration as a no-op.
+			continue
+		}
+		t.registeredTypes[vType] = true
+
+		model := t.GetModel(vType)
+		model.Name = prefix + t.NameMapper.Map(model.Name) + suffix
+		for _, field := range model.GetFields() {
+			field.SetColumnName(t.NameMapper.Map(field.Name()))
+		}
+		t.registeredModels = append(t.registeredModels, model)
+	}
+	// resolve every relation field now, the same checks BelongToPreload/
+	// OneToOnePreload/OneToManyPreload/ManyToManyPreload already do lazily,
+	// but turned into a panic so a broken relation fails at boot.
+	for _, model := range t.registeredModels {
+		for _, field := range model.GetFields() {
+			if !field.IsRelation() {
+				continue
+			}
+			if t.BelongToPreload(model, field) != nil {
+				continue
+			}
+			if t.OneToOnePreload(model, field) != nil {
+				continue
+			}
+			if t.OneToManyPreload(model, field) != nil {
+				continue
+			}
+			if t.ManyToManyPreload(model, field, true) != nil {
+				continue
+			}
+			panic(fmt.Sprintf("toyorm: cannot resolve relation field %s.%s", model.Name, field.Name()))
+		}
+	}
+}
+
+// RegisteredModels returns every *Model registered so far via RegisterModel
+// or one of its Prefix/Suffix variants, for use by external tooling such as
+// Sync or Migrate.
+func (t *Toy) RegisteredModels() []*Model {
+	return t.registeredModels
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright 2018. bigpigeon. All rights reserved.
+ * Use of this source code is governed by a MIT style
+ * license that can be found in the LICENSE file.
+ */
+
+package toyorm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSnakeCaseMapper(t *testing.T) {
+	cases := map[string]string{
+		"User":          "user",
+		"UserAddress":   "user_address",
+		"ID":            "id",
+		"UserID":        "user_id",
+		"commentableID": "commentable_id",
+	}
+	for name, want := range cases {
+		if got := (SnakeCaseMapper{}).Map(name); got != want {
+			t.Errorf("SnakeCaseMapper.Map(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestSameNameMapper(t *testing.T) {
+	if got := (SameNameMapper{}).Map("UserAddress"); got != "UserAddress" {
+		t.Errorf("SameNameMapper.Map should be a no-op, got %q", got)
+	}
+}
+
+func TestGonicMapper(t *testing.T) {
+	cases := map[string]string{
+		"User":        "user",
+		"UserID":      "user_id",
+		"UserIDURL":   "user_id_url",
+		"APIResponse": "api_response",
+	}
+	for name, want := range cases {
+		if got := (GonicMapper{}).Map(name); got != want {
+			t.Errorf("GonicMapper.Map(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestGonicMapperCustomInitialisms(t *testing.T) {
+	m := GonicMapper{Initialisms: map[string]bool{"DB": true}}
+	if got := m.Map("UserDBName"); got != "user_db_name" {
+		t.Errorf("GonicMapper.Map with custom initialisms = %q, want %q", got, "user_db_name")
+	}
+}
+
+type registryTestUser struct {
+	ID   int32 `toyorm:"primary key"`
+	Name string
+}
+
+// newTestToyKernel builds the same zero-value ToyKernel Open constructs,
+// minus the db-backed fields (Dialect, Logger, Tracer, Router) registerModel
+// never touches.
+func newTestToyKernel() ToyKernel {
+	return ToyKernel{
+		CacheModels:       map[reflect.Type]*Model{},
+		CacheMiddleModels: map[reflect.Type]*Model{},
+		belongToPreload:   map[*Model]map[string]*BelongToPreload{},
+		oneToOnePreload:   map[*Model]map[string]*OneToOnePreload{},
+		oneToManyPreload:  map[*Model]map[string]*OneToManyPreload{},
+		manyToManyPreload: map[*Model]map[string]map[bool]*ManyToManyPreload{},
+	}
+}
+
+func TestRegisterModelIdempotent(t *testing.T) {
+	toy := &Toy{
+		NameMapper:      SnakeCaseMapper{},
+		registeredTypes: map[reflect.Type]bool{},
+		ToyKernel:       newTestToyKernel(),
+	}
+	toy.RegisterModelWithPrefix("t_", registryTestUser{})
+	toy.RegisterModelWithPrefix("t_", registryTestUser{})
+
+	if len(toy.registeredModels) != 1 {
+		t.Fatalf("registering the same type twice should be a no-op the second time, got %d models", len(toy.registeredModels))
+	}
+	if name := toy.registeredModels[0].Name; name != "t_registry_test_user" {
+		t.Fatalf("prefix should be applied exactly once, got %q", name)
+	}
+}
+
+type registryTestBroken struct {
+	ID      int32 `toyorm:"primary key"`
+	Missing *registryTestUser
+}
+
+func TestRegisterModelPanicsOnUnresolvedRelation(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected registerModel to panic on an unresolvable relation field")
+		}
+	}()
+	toy := &Toy{
+		NameMapper:      SnakeCaseMapper{},
+		registeredTypes: map[reflect.Type]bool{},
+		ToyKernel:       newTestToyKernel(),
+	}
+	toy.RegisterModel(registryTestBroken{})
+}
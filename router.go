@@ -0,0 +1,110 @@
+/*
+ * Copyright 2018. bigpigeon. All rights reserved.
+ * Use of this source code is governed by a MIT style
+ * license that can be found in the LICENSE file.
+ */
+
+package toyorm
+
+import (
+	"database/sql"
+	"sync"
+	"sync/atomic"
+)
+
+// Router decides which *sql.DB a handler chain's operation should run
+// against. Toy's own Router implementation is a simple primary/replica
+// round-robin; callers with more elaborate topologies (sharding,
+// session-affinity) can install their own on ToyKernel.Router.
+type Router interface {
+	// Route returns the *sql.DB to use for op (e.g. "Find", "Insert") on
+	// table, given an optional session-affinity token. An empty token
+	// means "no affinity".
+	Route(op, table, sessionToken string) *sql.DB
+}
+
+// readWriteRouter sends read-only operations (only op "Find") to a
+// round-robin replica pool and everything else to the primary, the same
+// split Beego's db_alias and bun's multi-DB setups use. A non-empty
+// session token pins the call to the primary, for read-your-writes.
+//
+// replicas is stored behind replicasMu rather than atomic.Value because
+// AddReplica is rare (boot time/topology change) while Route is on every
+// read's hot path; a RWMutex lets concurrent Routes share the read lock.
+type readWriteRouter struct {
+	primary    *sql.DB
+	replicasMu sync.RWMutex
+	replicas   []*sql.DB
+	next       uint64
+}
+
+func newReadWriteRouter(primary *sql.DB) *readWriteRouter {
+	return &readWriteRouter{primary: primary}
+}
+
+func (r *readWriteRouter) Route(op, table, sessionToken string) *sql.DB {
+	r.replicasMu.RLock()
+	replicas := r.replicas
+	r.replicasMu.RUnlock()
+	if sessionToken != "" || !isReadOnlyOp(op) || len(replicas) == 0 {
+		return r.primary
+	}
+	i := atomic.AddUint64(&r.next, 1)
+	return replicas[i%uint64(len(replicas))]
+}
+
+func isReadOnlyOp(op string) bool {
+	switch op {
+	case "Find":
+		return true
+	default:
+		return false
+	}
+}
+
+// AddReplica opens dsn with driverName and adds it to the round-robin
+// replica pool used for Find. All replicas are expected to share t's
+// Dialect; AddReplica does not support mixing dialects across a topology.
+func (t *Toy) AddReplica(driverName, dsn string) error {
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return err
+	}
+	router, ok := t.Router.(*readWriteRouter)
+	if !ok {
+		router = newReadWriteRouter(t.db)
+		t.Router = router
+	}
+	router.replicasMu.Lock()
+	router.replicas = append(router.replicas, db)
+	router.replicasMu.Unlock()
+	return nil
+}
+
+// UsePrimary returns a copy of brick whose queries always route to the
+// primary database, even for otherwise-replica-eligible operations like
+// Find, for read-your-writes consistency right after a write.
+func (t *ToyBrick) UsePrimary() *ToyBrick {
+	newBrick := t.copy()
+	newBrick.sessionToken = "primary"
+	return newBrick
+}
+
+// DB resolves the *sql.DB that op should run against for this brick, via
+// ToyKernel.Router. Handlers must call this instead of reaching into
+// brick.toy.db directly, or AddReplica's replica pool is never actually
+// read from.
+func (t *ToyBrick) DB(op string) *sql.DB {
+	return t.toy.Router.Route(op, t.Model.Name, t.sessionToken)
+}
+
+// replicas returns the *sql.DB pool closed by Toy.Close, or nil if t's
+// Router isn't the built-in primary/replica one.
+func (t *Toy) replicas() []*sql.DB {
+	if router, ok := t.Router.(*readWriteRouter); ok {
+		router.replicasMu.RLock()
+		defer router.replicasMu.RUnlock()
+		return router.replicas
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright 2018. bigpigeon. All rights reserved.
+ * Use of this source code is governed by a MIT style
+ * license that can be found in the LICENSE file.
+ */
+
+package toyorm
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"time"
+)
+
+// TableInfo is the live shape of a table as reported by
+// Dialect.InspectTable, used by Sync to diff against a registered *Model.
+type TableInfo struct {
+	Name    string
+	Columns map[string]string // column name -> dialect-reported type
+	Indexes map[string]bool   // index name -> exists
+}
+
+// HandlerSyncTable participates in the "Sync" handler chain the same way
+// HandlerCreateTable does in CreateTable: it compares brick's *Model
+// against the database's current TableInfo and
+// issues any additive ALTER TABLE statements required to catch up.
+func HandlerSyncTable(brick *ToyBrick, r *Record) (next bool, err error) {
+	dialect, ok := brick.toy.Dialect.(SyncDialect)
+	if !ok {
+		return false, fmt.Errorf("toyorm: dialect %T does not support Sync", brick.toy.Dialect)
+	}
+	ctx, end := brick.toy.Tracer.StartSpan(brick.Context(), "Sync", brick.Model.Name)
+	defer func() { end(err) }()
+
+	info, err := dialect.InspectTable(ctx, brick.DB("Sync"), brick.Model.Name)
+	if err != nil {
+		return false, err
+	}
+	if info == nil {
+		return HandlerCreateTable(brick, r)
+	}
+	for _, exprs := range dialect.SyncTableExprs(brick.Model, info) {
+		if _, err = brick.DB("Sync").ExecContext(ctx, exprs.Query(), exprs.Args()...); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// SyncDialect is implemented by dialects that support Toy.Sync/Toy.Migrate.
+// It is kept separate from Dialect so existing dialects that only support
+// CreateTable keep compiling unchanged.
+type SyncDialect interface {
+	Dialect
+	// InspectTable returns the live shape of name, or nil if the table
+	// does not exist yet. ctx is forwarded to the underlying QueryContext
+	// call so callers can bound or cancel the inspection.
+	InspectTable(ctx context.Context, db *sql.DB, name string) (*TableInfo, error)
+	// SyncTableExprs returns the ALTER TABLE / CREATE INDEX / DROP INDEX
+	// statements needed to bring current up to model's shape. It never
+	// drops or narrows an existing column.
+	SyncTableExprs(model *Model, current *TableInfo) []SqlExpr
+}
+
+// Sync diffs each model's definition against the live database schema and
+// directly executes the statements the dialect's SyncTableExprs returns,
+// creating the table if it does not exist yet. With PostgresDialect that
+// means only missing columns are added; indexes are not created or dropped
+// and existing column types are not widened.
+func (t *Toy) Sync(models ...interface{}) error {
+	for _, v := range models {
+		brick := t.Model(v)
+		if _, err := t.ModelHandlers("Sync", brick.Model).Exec(brick); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Migrate is the review-before-apply counterpart to Sync: instead of
+// executing the diff, it writes a timestamped up/down SQL pair per model
+// into dir, the way a migration generator would.
+func (t *Toy) Migrate(dir string, models ...interface{}) error {
+	dialect, ok := t.Dialect.(SyncDialect)
+	if !ok {
+		return fmt.Errorf("toyorm: dialect %T does not support Migrate", t.Dialect)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	version := time.Now().UTC().Format("20060102150405")
+	for _, v := range models {
+		model := t.GetModel(LoopTypeIndirect(reflect.ValueOf(v).Type()))
+		info, err := dialect.InspectTable(context.Background(), t.db, model.Name)
+		if err != nil {
+			return err
+		}
+		var up []SqlExpr
+		if info == nil {
+			up = dialect.CreateTable(model, nil)
+		} else {
+			up = dialect.SyncTableExprs(model, info)
+		}
+		if err := writeMigrationFile(dir, version, model.Name, "up", up); err != nil {
+			return err
+		}
+		down := []SqlExpr{dialect.DropTable(model)}
+		if err := writeMigrationFile(dir, version, model.Name, "down", down); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeMigrationFile(dir, version, table, direction string, exprs []SqlExpr) error {
+	path := filepath.Join(dir, fmt.Sprintf("%s_%s.%s.sql", version, table, direction))
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	for _, e := range exprs {
+		if _, err := fmt.Fprintf(f, "%s;\n", e.Query()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
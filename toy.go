@@ -16,6 +16,9 @@ type Toy struct {
 	db                       *sql.DB
 	DefaultHandlerChain      map[string]HandlersChain
 	DefaultModelHandlerChain map[*Model]map[string]HandlersChain
+	NameMapper               NameMapper
+	registeredModels         []*Model
+	registeredTypes          map[reflect.Type]bool
 	ToyKernel
 }
 
@@ -24,24 +27,20 @@ func Open(driverName, dataSourceName string) (*Toy, error) {
 	if err != nil {
 		return nil, err
 	}
-	var dialect Dialect
-	switch driverName {
-	case "mysql":
-		dialect = MySqlDialect{}
-	case "sqlite3":
-		dialect = Sqlite3Dialect{}
-	default:
+	dialect, ok := dialects[driverName]
+	if !ok {
 		panic(ErrNotMatchDialect)
 	}
 	return &Toy{
 		db: db,
 		DefaultHandlerChain: map[string]HandlersChain{
 			"CreateTable":              {HandlerSimplePreload("CreateTable"), HandlerCreateTable},
+			"Sync":                     {HandlerSimplePreload("Sync"), HandlerSyncTable},
 			"CreateTableIfNotExist":    {HandlerSimplePreload("CreateTableIfNotExist"), HandlerExistTableAbort, HandlerCreateTable},
 			"DropTableIfExist":         {HandlerDropTablePreload("DropTableIfExist"), HandlerNotExistTableAbort, HandlerDropTable},
 			"DropTable":                {HandlerDropTablePreload("DropTable"), HandlerDropTable},
-			"Insert":                   {HandlerPreloadContainerCheck, HandlerPreloadInsertOrSave("Insert"), HandlerInsertTimeGenerate, HandlerInsert},
-			"Find":                     {HandlerPreloadContainerCheck, HandlerSoftDeleteCheck, HandlerFind, HandlerPreloadFind},
+			"Insert":                   {HandlerPreloadContainerCheck, HandlerPreloadInsertOrSave("Insert"), HandlerInsertTimeGenerate, HandlerInsertReturning, HandlerInsert},
+			"Find":                     {HandlerPreloadContainerCheck, HandlerSoftDeleteCheck, HandlerFind, HandlerPreloadFind, HandlerPolymorphicPreloadFind},
 			"Update":                   {HandlerSoftDeleteCheck, HandlerUpdateTimeGenerate, HandlerUpdate},
 			"Save":                     {HandlerPreloadContainerCheck, HandlerPreloadInsertOrSave("Save"), HandlerSaveTimeGenerate, HandlerSave},
 			"HardDelete":               {HandlerPreloadDelete, HandlerHardDelete},
@@ -50,6 +49,8 @@ func Open(driverName, dataSourceName string) (*Toy, error) {
 			"SoftDeleteWithPrimaryKey": {HandlerPreloadDelete, HandlerSearchWithPrimaryKey, HandlerSoftDelete},
 		},
 		DefaultModelHandlerChain: map[*Model]map[string]HandlersChain{},
+		NameMapper:               SnakeCaseMapper{},
+		registeredTypes:          map[reflect.Type]bool{},
 		ToyKernel: ToyKernel{
 			CacheModels:       map[reflect.Type]*Model{},
 			CacheMiddleModels: map[reflect.Type]*Model{},
@@ -60,6 +61,8 @@ func Open(driverName, dataSourceName string) (*Toy, error) {
 			manyToManyPreload: map[*Model]map[string]map[bool]*ManyToManyPreload{},
 			Dialect:           dialect,
 			Logger:            os.Stdout,
+			Tracer:            noopTracer{},
+			Router:            newReadWriteRouter(db),
 		},
 	}, nil
 }
@@ -89,7 +92,13 @@ func (t *Toy) ModelHandlers(option string, model *Model) HandlersChain {
 }
 
 func (t *Toy) Close() error {
-	return t.db.Close()
+	err := t.db.Close()
+	for _, replica := range t.replicas() {
+		if closeErr := replica.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
 }
 
 func (t *Toy) BelongToPreload(model *Model, field Field) *BelongToPreload {